@@ -0,0 +1,226 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// All returns a Future that resolves once every Future in fs has
+// resolved successfully, with a slice of their results in the same
+// order as fs. If any of the Futures in fs fails, All short-circuits:
+// its error is returned as soon as it is known, and the context used
+// to read the results of the remaining Futures in fs is cancelled (the
+// Futures themselves keep running to completion, as Futures always do,
+// but All stops waiting on them).
+//
+// Calling Eager on the returned Future starts evaluation of every
+// Future in fs. The returned Future is NonBlocking only if every
+// Future in fs is also NonBlocking.
+//
+// If a Future in fs panicked, that panic is forwarded to the caller of
+// Result on the returned Future, as with any other Future dependency.
+func All[T any](fs ...*Future[T]) *Future[[]T] {
+	f := NewFuture(func() ([]T, error) {
+		if len(fs) == 0 {
+			return nil, nil
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		res := make([]T, len(fs))
+		errCh := make(chan error, 1)
+		panicCh := make(chan any, 1)
+
+		var wg sync.WaitGroup
+		wg.Add(len(fs))
+		for i, fi := range fs {
+			// Eager fi before spawning its goroutine: Result only calls
+			// Eager if ctx isn't already cancelled, so if we left this to
+			// the goroutine a sibling that fails fast could cancel ctx
+			// before this goroutine is scheduled, and fi would never run
+			// at all instead of merely not being waited on.
+			fi.Eager()
+			go func(i int, fi *Future[T]) {
+				defer wg.Done()
+				defer func() {
+					// fi.Result panics (with a *FuturePanic) on this
+					// goroutine, not the caller's, if fi's wrapped
+					// function panicked: recover it here and let the
+					// caller of Result on the combined Future re-panic
+					// with it instead, mirroring how resolve() forwards
+					// a dependency's panic.
+					if r := recover(); r != nil {
+						select {
+						case panicCh <- r:
+							cancel()
+						default:
+						}
+					}
+				}()
+				r, err := fi.Result(ctx)
+				if err != nil {
+					select {
+					case errCh <- err:
+						cancel()
+					default:
+					}
+					return
+				}
+				res[i] = r
+			}(i, fi)
+		}
+		wg.Wait()
+
+		select {
+		case r := <-panicCh:
+			panic(r)
+		default:
+		}
+
+		select {
+		case err := <-errCh:
+			return nil, err
+		default:
+			return res, nil
+		}
+	})
+	if allNonBlocking(fs) {
+		f.NonBlocking()
+	}
+	return f
+}
+
+// Any returns a Future that resolves to the result of the first Future
+// in fs to succeed. If every Future in fs fails, Any fails with all of
+// their errors joined together (see errors.Join). As soon as one
+// Future succeeds, the context used to read the results of the
+// remaining Futures in fs is cancelled.
+//
+// Calling Eager on the returned Future starts evaluation of every
+// Future in fs. The returned Future is NonBlocking only if every
+// Future in fs is also NonBlocking.
+//
+// If a Future in fs panicked, that panic is forwarded to the caller of
+// Result on the returned Future, as with any other Future dependency.
+func Any[T any](fs ...*Future[T]) *Future[T] {
+	f := NewFuture(func() (T, error) {
+		var zero T
+		if len(fs) == 0 {
+			return zero, errors.New("async: Any requires at least one Future")
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		errs := make([]error, len(fs))
+		resCh := make(chan T, 1)
+		panicCh := make(chan any, 1)
+
+		var wg sync.WaitGroup
+		wg.Add(len(fs))
+		for i, fi := range fs {
+			// See the equivalent comment in All: Eager fi before spawning
+			// its goroutine so a sibling that succeeds fast can't cancel
+			// ctx before this goroutine gets to call Eager itself, which
+			// would skip running fi's wrapped function entirely.
+			fi.Eager()
+			go func(i int, fi *Future[T]) {
+				defer wg.Done()
+				defer func() {
+					// See the equivalent defer in All: fi.Result panics
+					// on this goroutine, not the caller's, so recover it
+					// here and let the caller of Result on the combined
+					// Future re-panic with it instead.
+					if r := recover(); r != nil {
+						select {
+						case panicCh <- r:
+							cancel()
+						default:
+						}
+					}
+				}()
+				r, err := fi.Result(ctx)
+				if err != nil {
+					errs[i] = err
+					return
+				}
+				select {
+				case resCh <- r:
+					cancel()
+				default:
+				}
+			}(i, fi)
+		}
+		wg.Wait()
+
+		select {
+		case r := <-panicCh:
+			panic(r)
+		default:
+		}
+
+		select {
+		case r := <-resCh:
+			return r, nil
+		default:
+			return zero, errors.Join(errs...)
+		}
+	})
+	if allNonBlocking(fs) {
+		f.NonBlocking()
+	}
+	return f
+}
+
+// Map returns a Future that resolves fn against the result of f, once
+// f has resolved successfully. If f fails, fn is not invoked and the
+// returned Future fails with f's error.
+//
+// The returned Future is NonBlocking only if f is also NonBlocking.
+func Map[T, U any](f *Future[T], fn func(T) (U, error)) *Future[U] {
+	g := NewFuture(func() (U, error) {
+		var zero U
+		r, err := f.Result(context.Background())
+		if err != nil {
+			return zero, err
+		}
+		return fn(r)
+	})
+	if f.nonBlocking.Load() {
+		g.NonBlocking()
+	}
+	return g
+}
+
+// Then returns a Future that, once f has resolved successfully, invokes
+// fn with its result to obtain a dependent Future and flattens it,
+// so that the caller does not have to hand-write a Future whose function
+// does nothing but call Result on another Future. If f fails, fn is not
+// invoked and the returned Future fails with f's error.
+//
+// Unlike All, Any and Map, Then never makes its result NonBlocking, even
+// if f is: the Future returned by fn is only known once fn(r) has run,
+// so there is no NonBlocking flag to inspect upfront, and assuming one
+// could reintroduce blocking inside what the caller believes to be a
+// fast, non-blocking Eager or Done call.
+func Then[T, U any](f *Future[T], fn func(T) *Future[U]) *Future[U] {
+	return NewFuture(func() (U, error) {
+		var zero U
+		r, err := f.Result(context.Background())
+		if err != nil {
+			return zero, err
+		}
+		return fn(r).Result(context.Background())
+	})
+}
+
+func allNonBlocking[T any](fs []*Future[T]) bool {
+	for _, f := range fs {
+		if !f.nonBlocking.Load() {
+			return false
+		}
+	}
+	return true
+}
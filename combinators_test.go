@@ -0,0 +1,300 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAll(t *testing.T) {
+	f1 := NewFuture(func() (int, error) { return 1, nil })
+	f2 := NewFuture(func() (int, error) { return 2, nil })
+	f3 := NewFuture(func() (int, error) { return 3, nil })
+
+	res, err := All(f1, f2, f3).Result(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 3 || res[0] != 1 || res[1] != 2 || res[2] != 3 {
+		t.Fatalf("res: %v", res)
+	}
+}
+
+func TestAllEmpty(t *testing.T) {
+	res, err := All[int]().Result(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 0 {
+		t.Fatalf("res: %v", res)
+	}
+}
+
+func TestAllError(t *testing.T) {
+	wantErr := errors.New("boom")
+	f1 := NewFuture(func() (int, error) { return 1, nil })
+	f2 := NewFuture(func() (int, error) { return 0, wantErr })
+
+	_, err := All(f1, f2).Result(context.Background())
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("err: %v", err)
+	}
+}
+
+func TestAllPanic(t *testing.T) {
+	f1 := NewFuture(func() (int, error) { return 1, nil })
+	f2 := NewFuture(func() (int, error) { panic("boom") })
+
+	defer func() {
+		r := recover()
+		if _, ok := r.(*FuturePanic); !ok {
+			t.Fatalf("r: %v", r)
+		}
+	}()
+	All(f1, f2).Result(context.Background())
+}
+
+func TestAllNonBlockingPropagation(t *testing.T) {
+	nb1 := NewFuture(func() (int, error) { return 1, nil }).NonBlocking()
+	nb2 := NewFuture(func() (int, error) { return 2, nil }).NonBlocking()
+	blocking := NewFuture(func() (int, error) { return 3, nil })
+
+	if combined := All(nb1, nb2); !combined.nonBlocking.Load() {
+		t.Fatal("expected NonBlocking to propagate when every input is NonBlocking")
+	}
+	if combined := All(nb1, blocking); combined.nonBlocking.Load() {
+		t.Fatal("expected NonBlocking to not propagate when an input is not NonBlocking")
+	}
+	if combined := All[int](); !combined.nonBlocking.Load() {
+		t.Fatal("expected NonBlocking to propagate for an empty All")
+	}
+}
+
+func TestAllEagerCascade(t *testing.T) {
+	// fs[0] fails immediately, which cancels the context All uses to
+	// read the other inputs' results. The other inputs must still be
+	// started (just not waited on), even though their own Eager/Done
+	// is never touched by this test, and even though their goroutines
+	// are still running when Result returns.
+	const n = 3
+	started := make([]chan struct{}, n)
+	release := make(chan struct{})
+
+	fs := make([]*Future[int], n)
+	fs[0] = NewFuture(func() (int, error) {
+		return 0, errors.New("boom")
+	})
+	for i := 1; i < n; i++ {
+		i := i
+		started[i] = make(chan struct{})
+		fs[i] = NewFuture(func() (int, error) {
+			close(started[i])
+			<-release
+			return i, nil
+		})
+	}
+
+	if _, err := All(fs...).Result(context.Background()); err == nil {
+		t.Fatal("expected an error")
+	}
+	for i := 1; i < n; i++ {
+		select {
+		case <-started[i]:
+		case <-time.After(time.Second):
+			t.Fatalf("input %d was not started, even though All only stops waiting on it", i)
+		}
+	}
+	close(release)
+}
+
+func TestAny(t *testing.T) {
+	wantErr := errors.New("boom")
+	f1 := NewFuture(func() (int, error) { return 0, wantErr })
+	f2 := NewFuture(func() (int, error) { return 42, nil })
+
+	res, err := Any(f1, f2).Result(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res != 42 {
+		t.Fatalf("res: %v", res)
+	}
+}
+
+func TestAnyAllFail(t *testing.T) {
+	err1 := errors.New("boom1")
+	err2 := errors.New("boom2")
+	f1 := NewFuture(func() (int, error) { return 0, err1 })
+	f2 := NewFuture(func() (int, error) { return 0, err2 })
+
+	_, err := Any(f1, f2).Result(context.Background())
+	if !errors.Is(err, err1) || !errors.Is(err, err2) {
+		t.Fatalf("err: %v", err)
+	}
+}
+
+func TestAnyPanic(t *testing.T) {
+	f1 := NewFuture(func() (int, error) { return 0, errors.New("boom") })
+	f2 := NewFuture(func() (int, error) { panic("boom") })
+
+	defer func() {
+		r := recover()
+		if _, ok := r.(*FuturePanic); !ok {
+			t.Fatalf("r: %v", r)
+		}
+	}()
+	Any(f1, f2).Result(context.Background())
+}
+
+func TestAnyEmpty(t *testing.T) {
+	_, err := Any[int]().Result(context.Background())
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestAnyNonBlockingPropagation(t *testing.T) {
+	nb1 := NewFuture(func() (int, error) { return 1, nil }).NonBlocking()
+	nb2 := NewFuture(func() (int, error) { return 2, nil }).NonBlocking()
+	blocking := NewFuture(func() (int, error) { return 3, nil })
+
+	if combined := Any(nb1, nb2); !combined.nonBlocking.Load() {
+		t.Fatal("expected NonBlocking to propagate when every input is NonBlocking")
+	}
+	if combined := Any(nb1, blocking); combined.nonBlocking.Load() {
+		t.Fatal("expected NonBlocking to not propagate when an input is not NonBlocking")
+	}
+}
+
+func TestAnyEagerCascade(t *testing.T) {
+	// fs[0] succeeds immediately, which cancels the context Any uses to
+	// read the other inputs' results. The other inputs must still be
+	// started (just not waited on), even though their own Eager/Done is
+	// never touched by this test, and even though their goroutines are
+	// still running when Result returns.
+	const n = 3
+	started := make([]chan struct{}, n)
+	release := make(chan struct{})
+
+	fs := make([]*Future[int], n)
+	fs[0] = NewFuture(func() (int, error) {
+		return 0, nil
+	})
+	for i := 1; i < n; i++ {
+		i := i
+		started[i] = make(chan struct{})
+		fs[i] = NewFuture(func() (int, error) {
+			close(started[i])
+			<-release
+			return i, nil
+		})
+	}
+
+	res, err := Any(fs...).Result(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res != 0 {
+		t.Fatalf("res: %v", res)
+	}
+	for i := 1; i < n; i++ {
+		select {
+		case <-started[i]:
+		case <-time.After(time.Second):
+			t.Fatalf("input %d was not started, even though Any only stops waiting on it", i)
+		}
+	}
+	close(release)
+}
+
+func TestMap(t *testing.T) {
+	f := NewFuture(func() (int, error) { return 21, nil })
+	g := Map(f, func(n int) (int, error) { return n * 2, nil })
+
+	res, err := g.Result(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res != 42 {
+		t.Fatalf("res: %v", res)
+	}
+}
+
+func TestMapError(t *testing.T) {
+	wantErr := errors.New("boom")
+	f := NewFuture(func() (int, error) { return 0, wantErr })
+	g := Map(f, func(n int) (int, error) { return n * 2, nil })
+
+	_, err := g.Result(context.Background())
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("err: %v", err)
+	}
+}
+
+func TestMapNonBlockingPropagation(t *testing.T) {
+	nb := NewFuture(func() (int, error) { return 21, nil }).NonBlocking()
+	if g := Map(nb, func(n int) (int, error) { return n * 2, nil }); !g.nonBlocking.Load() {
+		t.Fatal("expected NonBlocking to propagate when the input is NonBlocking")
+	}
+
+	blocking := NewFuture(func() (int, error) { return 21, nil })
+	if g := Map(blocking, func(n int) (int, error) { return n * 2, nil }); g.nonBlocking.Load() {
+		t.Fatal("expected NonBlocking to not propagate when the input is not NonBlocking")
+	}
+}
+
+func TestMapEagerCascade(t *testing.T) {
+	var started atomic.Bool
+	f := NewFuture(func() (int, error) {
+		started.Store(true)
+		return 21, nil
+	})
+
+	g := Map(f, func(n int) (int, error) { return n * 2, nil })
+	g.Eager()
+	<-f.Done()
+	if !started.Load() {
+		t.Fatal("input was not started by Eager")
+	}
+	g.Result(context.Background())
+}
+
+func TestThen(t *testing.T) {
+	f := NewFuture(func() (int, error) { return 21, nil })
+	g := Then(f, func(n int) *Future[string] {
+		return NewFuture(func() (string, error) { return "hello", nil })
+	})
+
+	res, err := g.Result(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res != "hello" {
+		t.Fatalf("res: %v", res)
+	}
+}
+
+func TestThenError(t *testing.T) {
+	wantErr := errors.New("boom")
+	f := NewFuture(func() (int, error) { return 0, wantErr })
+	g := Then(f, func(n int) *Future[string] {
+		return NewFuture(func() (string, error) { return "hello", nil })
+	})
+
+	_, err := g.Result(context.Background())
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("err: %v", err)
+	}
+}
+
+func TestThenNonBlockingNotPropagated(t *testing.T) {
+	nb := NewFuture(func() (int, error) { return 21, nil }).NonBlocking()
+	g := Then(nb, func(n int) *Future[string] {
+		return NewFuture(func() (string, error) { return "hello", nil })
+	})
+	if g.nonBlocking.Load() {
+		t.Fatal("Then must not propagate NonBlocking from f")
+	}
+}
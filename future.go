@@ -2,7 +2,7 @@ package async
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"runtime"
 	"runtime/debug"
 	"sync"
@@ -27,9 +27,13 @@ type Future[T any] struct {
 	done     chan struct{}
 
 	onceEager sync.Once
+
+	scheduler Scheduler
+
+	shared atomic.Int32
 }
 
-var panicHook func(*panicError) // for testing
+var panicHook func(*FuturePanic) // for testing
 
 // NewFuture wraps the provided function into a Future handle that can
 // be used to asynchronously execute the function and obtain its results.
@@ -46,13 +50,13 @@ func NewFuture[T any](fn func() (T, error)) *Future[T] {
 	}
 	w := &Future[T]{fn: fn}
 	runtime.SetFinalizer(w, func(w *Future[T]) {
-		if perr, ok := w.err.(*panicError); ok {
+		if fp, ok := w.err.(*FuturePanic); ok {
 			defer func() {
 				if panicHook != nil && recover() != nil {
-					panicHook(perr)
+					panicHook(fp)
 				}
 			}()
-			panic("ignored: " + perr.Error())
+			panic("ignored: " + fp.Error())
 		}
 	})
 	return w
@@ -79,6 +83,19 @@ func (w *Future[T]) NonBlocking() *Future[T] {
 	return w
 }
 
+// WithScheduler makes Eager submit the wrapped function to s instead of
+// the package's default Scheduler (see SetDefaultScheduler). This is
+// useful to bound the number of Futures executing concurrently, e.g.
+// with a BoundedScheduler, instead of spawning a goroutine per Future.
+//
+// WithScheduler, if used, should be called before any call to Eager,
+// Done, Result, or Resolve. It has no effect on a Future made
+// NonBlocking, since such a Future is always resolved synchronously.
+func (w *Future[T]) WithScheduler(s Scheduler) *Future[T] {
+	w.scheduler = s
+	return w
+}
+
 // Eager signals to the Future runtime that execution of the wrapped
 // function should be started now (if it has not been started yet).
 //
@@ -90,19 +107,30 @@ func (w *Future[T]) Eager() {
 	w.onceEager.Do(func() {
 		if w.nonBlocking.Load() {
 			w.resolve()
-		} else {
-			go w.resolve()
+			return
 		}
+		s := w.scheduler
+		if s == nil {
+			s = defaultScheduler()
+		}
+		s.Submit(w.resolve)
 	})
 }
 
 func (w *Future[T]) resolve() {
 	w.once.Do(func() {
+		normalReturn := false
+		recovered := false
 		defer func() {
-			w.fn = nil
-			if r := recover(); r != nil {
-				w.err = &panicError{recovered: r, stackTrace: debug.Stack()}
+			// The order of the deferred functions matters: this one
+			// must run after the recover() below, so that it only
+			// fires when neither a normal return nor a panic
+			// accounts for fn having stopped running, i.e. when fn
+			// (or something it called) invoked runtime.Goexit.
+			if !normalReturn && !recovered {
+				w.err = errGoexit
 			}
+			w.fn = nil
 			w.onceDone.Do(func() {
 				if w.done == nil {
 					w.done = closedChan
@@ -112,14 +140,45 @@ func (w *Future[T]) resolve() {
 				close(w.done)
 			}
 		}()
-		w.res, w.err = w.fn()
+		func() {
+			defer func() {
+				if !normalReturn {
+					if r := recover(); r != nil {
+						if fp, ok := r.(*FuturePanic); ok {
+							// fn panicked while forwarding a panic that
+							// already crossed another Future's boundary
+							// (e.g. it called Result on a dependent
+							// Future): record this hop instead of
+							// wrapping it again. fp may be shared with
+							// other Futures forwarding the same panic
+							// concurrently (e.g. siblings in an All or
+							// Any), so the append is synchronized.
+							fp.addTrace(debug.Stack())
+							w.err = fp
+						} else {
+							w.err = &FuturePanic{Value: r, Stack: debug.Stack()}
+						}
+						recovered = true
+					}
+				}
+			}()
+			w.res, w.err = w.fn()
+			normalReturn = true
+		}()
 	})
 }
 
 func (w *Future[T]) result(doPanic bool) (T, error) {
-	if perr, ok := w.err.(*panicError); ok && doPanic {
+	if !doPanic {
+		return w.res, w.err
+	}
+	if fp, ok := w.err.(*FuturePanic); ok {
 		runtime.SetFinalizer(w, nil)
-		panic(perr.Error())
+		panic(fp)
+	}
+	if w.err == errGoexit {
+		runtime.SetFinalizer(w, nil)
+		runtime.Goexit()
 	}
 	return w.res, w.err
 }
@@ -135,7 +194,14 @@ func (w *Future[T]) result(doPanic bool) (T, error) {
 // complete) with the error from the context.
 //
 // If the wrapped function panicked, Result will propagate that panic
-// to each function that calls Result.
+// to each function that calls Result. The panic value is a *FuturePanic,
+// which can be recovered with errors.As regardless of how many
+// dependent Futures it was forwarded through.
+//
+// If the wrapped function called runtime.Goexit instead of returning
+// (for example via a t.FailNow() in a test), Result re-issues
+// runtime.Goexit on the calling goroutine rather than returning a
+// zero result.
 func (w *Future[T]) Result(ctx context.Context) (T, error) {
 	ctxDone := ctx.Done()
 
@@ -199,6 +265,16 @@ func (w *Future[T]) _done() <-chan struct{} {
 	return w.done
 }
 
+// Shared returns the number of callers that coalesced onto this Future
+// instead of triggering their own invocation of the wrapped function.
+// It is primarily useful for Futures created through a Group, where
+// concurrent callers requesting the same key are deduplicated onto a
+// single Future; for Futures created directly via NewFuture it is
+// always 0.
+func (w *Future[T]) Shared() int {
+	return int(w.shared.Load())
+}
+
 // Resolve synchronously invokes the wrapped function if it has not
 // been invoked yet. It returns the error returned by the invocation.
 //
@@ -209,14 +285,12 @@ func (w *Future[T]) Resolve() error {
 	return err
 }
 
-type panicError struct {
-	recovered  any
-	stackTrace []byte
-}
-
-func (p *panicError) Error() string {
-	return fmt.Sprintf("panic: %v\n%s", p.recovered, p.stackTrace)
-}
+// errGoexit is recorded as a Future's error when its wrapped function
+// returns neither normally nor via a panic, i.e. when it (or something
+// it calls, such as testing.T.FailNow) invokes runtime.Goexit. Result
+// reacts to it by re-issuing runtime.Goexit on the calling goroutine,
+// rather than returning a bogus zero result.
+var errGoexit = errors.New("async: wrapped function called runtime.Goexit")
 
 var closedChan chan struct{}
 
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"runtime"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 )
@@ -53,18 +54,90 @@ func TestFuturePanic(t *testing.T) {
 
 	defer func() {
 		r := recover()
-		if !strings.HasPrefix(r.(string), "panic: at the disco") {
+		fp, ok := r.(*FuturePanic)
+		if !ok {
 			t.Fatalf("r: %v", r)
 		}
+		if !strings.HasPrefix(fp.Error(), "panic: at the disco") {
+			t.Fatalf("r: %v", r)
+		}
+		if fp.Value != "at the disco" {
+			t.Fatalf("value: %v", fp.Value)
+		}
 	}()
 	f.Result(ctx)
 }
 
+func TestFuturePanicAs(t *testing.T) {
+	f := NewFuture(func() (int, error) {
+		panic("at the disco")
+	})
+
+	defer func() {
+		r := recover()
+		var fp *FuturePanic
+		if !errors.As(r.(error), &fp) {
+			t.Fatalf("errors.As failed on: %v", r)
+		}
+	}()
+	f.Result(context.Background())
+}
+
+func TestFuturePanicForwarded(t *testing.T) {
+	f1 := NewFuture(func() (int, error) {
+		panic("at the disco")
+	})
+	f2 := NewFuture(func() (int, error) {
+		return f1.Result(context.Background())
+	})
+
+	defer func() {
+		r := recover()
+		fp, ok := r.(*FuturePanic)
+		if !ok {
+			t.Fatalf("r: %v", r)
+		}
+		if fp.Value != "at the disco" {
+			t.Fatalf("value: %v", fp.Value)
+		}
+		if len(fp.Trace()) != 1 {
+			t.Fatalf("trace: %v", fp.Trace())
+		}
+	}()
+	f2.Result(context.Background())
+}
+
+func TestFuturePanicForwardedConcurrently(t *testing.T) {
+	f1 := NewFuture(func() (int, error) {
+		panic("at the disco")
+	})
+
+	const n = 8
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			f2 := NewFuture(func() (int, error) {
+				return f1.Result(context.Background())
+			})
+			defer func() {
+				r := recover()
+				if _, ok := r.(*FuturePanic); !ok {
+					t.Errorf("r: %v", r)
+				}
+			}()
+			f2.Result(context.Background())
+		}()
+	}
+	wg.Wait()
+}
+
 func TestFuturePanicIgnored(t *testing.T) {
 	panicked := false
-	panicHook = func(perr *panicError) {
-		t.Logf("perr: %q", perr)
-		panicked = strings.HasPrefix(perr.Error(), "panic: at the disco")
+	panicHook = func(fp *FuturePanic) {
+		t.Logf("fp: %q", fp)
+		panicked = strings.HasPrefix(fp.Error(), "panic: at the disco")
 	}
 	t.Cleanup(func() {
 		panicHook = nil
@@ -166,6 +239,30 @@ func ExampleFuture() {
 	f4.Result(ctx)
 }
 
+func TestFutureGoexit(t *testing.T) {
+	f := NewFuture(func() (int, error) {
+		runtime.Goexit()
+		return 42, nil
+	})
+	f.Eager()
+	<-f.Done()
+
+	// The function already ran to completion (via Goexit) in Eager's
+	// goroutine. Every subsequent caller of Result, on every goroutine,
+	// must also observe the Goexit instead of a bogus zero result.
+	done := make(chan bool, 1)
+	go func() {
+		defer func() {
+			done <- false
+		}()
+		f.Result(context.Background())
+		done <- true
+	}()
+	if completed := <-done; completed {
+		t.Fatal("goroutine should have exited via runtime.Goexit, not returned normally")
+	}
+}
+
 func TestFutureResolve(t *testing.T) {
 	f := NewFuture(func() (int, error) {
 		return 42, nil
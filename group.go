@@ -0,0 +1,69 @@
+package async
+
+import "sync"
+
+// Group coalesces concurrent requests for the same key onto a single
+// shared Future, similarly to golang.org/x/sync/singleflight. Unlike
+// singleflight, Do never blocks the caller: it always returns
+// immediately with a *Future[T] handle that the caller can then
+// resolve using the usual Future API.
+//
+// The zero value of Group is ready to use.
+type Group[K comparable, T any] struct {
+	mu sync.Mutex
+	m  map[K]*Future[T]
+}
+
+// Do returns the in-flight Future for key, if one exists and has not
+// completed yet; callers that join an in-flight Future increment its
+// Shared counter. Otherwise it wraps fn into a new Future, registers it
+// under key, and returns it. As with any other Future, the returned
+// Future is not started automatically: it is invoked at most once, the
+// first time it is driven via Eager, Done or Result.
+//
+// The Group entry for key is removed as part of that single invocation
+// of fn, under the same lock used to register and look up entries, so
+// the key can never be seen as "free" before fn has actually finished
+// running. This is what lets concurrent callers of Do reliably coalesce
+// onto the same Future: a lookup either joins the Future still
+// registered for key, or - once fn has genuinely completed - starts a
+// fresh one.
+func (g *Group[K, T]) Do(key K, fn func() (T, error)) *Future[T] {
+	g.mu.Lock()
+	if f, ok := g.m[key]; ok {
+		f.shared.Add(1)
+		g.mu.Unlock()
+		return f
+	}
+
+	var f *Future[T]
+	f = NewFuture(func() (T, error) {
+		defer func() {
+			g.mu.Lock()
+			if g.m[key] == f {
+				delete(g.m, key)
+			}
+			g.mu.Unlock()
+		}()
+		return fn()
+	})
+	if g.m == nil {
+		g.m = make(map[K]*Future[T])
+	}
+	g.m[key] = f
+	g.mu.Unlock()
+
+	return f
+}
+
+// Forget removes key from the Group, if present, so that the next call
+// to Do for that key starts a new invocation of its function instead of
+// coalescing onto a Future that is already in flight or completed.
+//
+// Forget does not cancel or otherwise affect the Future itself: callers
+// that already obtained it via Do keep a valid handle.
+func (g *Group[K, T]) Forget(key K) {
+	g.mu.Lock()
+	delete(g.m, key)
+	g.mu.Unlock()
+}
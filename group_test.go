@@ -0,0 +1,113 @@
+package async
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGroupDo(t *testing.T) {
+	var calls atomic.Int32
+	var g Group[string, int]
+
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	futures := make([]*Future[int], 10)
+	for i := range futures {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			futures[i] = g.Do("k", func() (int, error) {
+				calls.Add(1)
+				return 42, nil
+			})
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	for _, f := range futures {
+		res, err := f.Result(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res != 42 {
+			t.Fatalf("res: %v", res)
+		}
+	}
+	if c := calls.Load(); c != 1 {
+		t.Fatalf("calls: %v", c)
+	}
+}
+
+func TestGroupDoDifferentKeys(t *testing.T) {
+	var g Group[string, int]
+	f1 := g.Do("a", func() (int, error) { return 1, nil })
+	f2 := g.Do("b", func() (int, error) { return 2, nil })
+
+	r1, _ := f1.Result(context.Background())
+	r2, _ := f2.Result(context.Background())
+	if r1 != 1 || r2 != 2 {
+		t.Fatalf("r1: %v, r2: %v", r1, r2)
+	}
+}
+
+func TestGroupForget(t *testing.T) {
+	var calls atomic.Int32
+	var g Group[string, int]
+
+	fn := func() (int, error) {
+		calls.Add(1)
+		return int(calls.Load()), nil
+	}
+
+	f1 := g.Do("k", fn)
+	f1.Result(context.Background())
+
+	g.Forget("k")
+
+	f2 := g.Do("k", fn)
+	f2.Result(context.Background())
+
+	if c := calls.Load(); c != 2 {
+		t.Fatalf("calls: %v", c)
+	}
+}
+
+func TestGroupShared(t *testing.T) {
+	var g Group[string, int]
+	waitCh := make(chan struct{})
+	f1 := g.Do("k", func() (int, error) {
+		<-waitCh
+		return 42, nil
+	})
+	f2 := g.Do("k", func() (int, error) {
+		return 0, nil
+	})
+	if f1 != f2 {
+		t.Fatal("expected the same Future to be returned")
+	}
+	if s := f2.Shared(); s != 1 {
+		t.Fatalf("shared: %v", s)
+	}
+	close(waitCh)
+	f1.Result(context.Background())
+}
+
+func TestGroupCleansUpAfterCompletion(t *testing.T) {
+	var g Group[string, int]
+	f1 := g.Do("k", func() (int, error) { return 1, nil })
+	f1.Result(context.Background())
+
+	// The entry is removed as part of resolving f1 itself (see Do), so
+	// by the time Result has returned it is already gone - no polling
+	// needed.
+	g.mu.Lock()
+	_, ok := g.m["k"]
+	g.mu.Unlock()
+	if ok {
+		t.Fatal("expected entry to be removed from the Group")
+	}
+}
@@ -0,0 +1,58 @@
+package async
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// FuturePanic is the error recorded by a Future, and re-panicked by
+// Result, when the wrapped function (or something it calls) panics.
+//
+// Unlike a plain recover()ed value, FuturePanic survives being forwarded
+// through any number of dependent Futures (as in the composition pattern
+// shown in ExampleFuture): Value and Stack always describe the original
+// panic, at the Future where it actually occurred, while Trace accumulates
+// one stack trace for every intermediate Future that re-panicked while
+// forwarding it to a caller further up the chain. errors.As can be used
+// to recover a *FuturePanic from a recovered panic value.
+//
+// A single FuturePanic can be forwarded by more than one Future
+// concurrently - for example when several Futures built with All or Any
+// depend on the same failing Future and call its Result from their own,
+// separate goroutines - so appends to Trace are synchronized internally;
+// the order of entries reflects the order in which those Futures
+// finished forwarding the value, not any particular topological order.
+type FuturePanic struct {
+	// Value is the original value passed to panic().
+	Value any
+	// Stack is the stack trace captured where Value was originally
+	// recovered.
+	Stack []byte
+
+	traceMu sync.Mutex
+	trace   [][]byte
+}
+
+// Trace returns one stack trace for each Future that re-panicked with
+// this value while forwarding it to a caller further up the chain.
+func (p *FuturePanic) Trace() [][]byte {
+	p.traceMu.Lock()
+	defer p.traceMu.Unlock()
+	return p.trace
+}
+
+func (p *FuturePanic) addTrace(stack []byte) {
+	p.traceMu.Lock()
+	p.trace = append(p.trace, stack)
+	p.traceMu.Unlock()
+}
+
+func (p *FuturePanic) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "panic: %v\n%s", p.Value, p.Stack)
+	for _, t := range p.Trace() {
+		fmt.Fprintf(&b, "\nforwarded by:\n%s", t)
+	}
+	return b.String()
+}
@@ -0,0 +1,148 @@
+package async
+
+import "sync"
+
+// Scheduler decides how and when a function submitted via Submit is
+// actually executed. It is used by Future to control how the wrapped
+// function is run once Eager (directly, or via Done or Result) starts
+// its execution.
+//
+// Submit must eventually run fn, possibly on a different goroutine than
+// the one that called Submit. Implementations must be safe for
+// concurrent use.
+type Scheduler interface {
+	Submit(fn func())
+}
+
+// goroutineScheduler is the Scheduler used by Future by default: it
+// spawns a new goroutine for every submitted function, matching the
+// module's original (unbounded) behavior.
+type goroutineScheduler struct{}
+
+func (goroutineScheduler) Submit(fn func()) {
+	go runTask(fn)
+}
+
+// NewGoroutineScheduler returns a Scheduler that runs every submitted
+// function on its own goroutine, without any bound on the number of
+// goroutines running concurrently. This is the Scheduler used by
+// Future when none is set via SetDefaultScheduler or WithScheduler.
+func NewGoroutineScheduler() Scheduler {
+	return goroutineScheduler{}
+}
+
+var (
+	defaultSchedulerMu sync.RWMutex
+	defaultSchedulerV  Scheduler = goroutineScheduler{}
+)
+
+// SetDefaultScheduler replaces the Scheduler used by Futures that have
+// not been given one explicitly via WithScheduler. It affects every
+// Future created afterwards, as well as any existing Future whose
+// execution has not started yet.
+//
+// The default Scheduler, if SetDefaultScheduler is never called, is the
+// one returned by NewGoroutineScheduler.
+func SetDefaultScheduler(s Scheduler) {
+	if s == nil {
+		panic("nil scheduler")
+	}
+	defaultSchedulerMu.Lock()
+	defaultSchedulerV = s
+	defaultSchedulerMu.Unlock()
+}
+
+func defaultScheduler() Scheduler {
+	defaultSchedulerMu.RLock()
+	defer defaultSchedulerMu.RUnlock()
+	return defaultSchedulerV
+}
+
+// QueuePolicy controls what a BoundedScheduler does with a submitted
+// function once its queue is full.
+type QueuePolicy int
+
+const (
+	// QueueBlock makes Submit block until there is room for the
+	// function in the queue. This is the policy used by
+	// NewBoundedScheduler.
+	QueueBlock QueuePolicy = iota
+	// QueueInline makes Submit run the function on the calling
+	// goroutine instead of blocking, once the queue is full.
+	QueueInline
+)
+
+// BoundedScheduler is a Scheduler backed by a fixed-size pool of worker
+// goroutines and a submit queue. With QueueBlock, the number of
+// functions running concurrently is bounded by the number of workers
+// regardless of how many Futures are made Eager. With QueueInline, a
+// function submitted while the queue is full runs on the submitting
+// goroutine, so it is not counted against that bound.
+type BoundedScheduler struct {
+	tasks  chan func()
+	policy QueuePolicy
+}
+
+// NewBoundedScheduler returns a Scheduler backed by n worker goroutines.
+// Once all workers are busy and the submit queue (sized n) is also
+// full, Submit blocks until a slot becomes free.
+//
+// NewBoundedScheduler panics if n is not positive.
+func NewBoundedScheduler(n int) *BoundedScheduler {
+	return NewBoundedSchedulerQueue(n, n, QueueBlock)
+}
+
+// NewBoundedSchedulerQueue returns a Scheduler backed by workers worker
+// goroutines and a submit queue of size queueSize. policy controls what
+// happens when that queue is full.
+//
+// NewBoundedSchedulerQueue panics if workers is not positive.
+func NewBoundedSchedulerQueue(workers, queueSize int, policy QueuePolicy) *BoundedScheduler {
+	if workers <= 0 {
+		panic("async: workers must be > 0")
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+	s := &BoundedScheduler{tasks: make(chan func(), queueSize), policy: policy}
+	for i := 0; i < workers; i++ {
+		go s.worker()
+	}
+	return s
+}
+
+func (s *BoundedScheduler) worker() {
+	for fn := range s.tasks {
+		runTask(fn)
+	}
+}
+
+// runTask runs fn, recovering from any panic so that one bad task can't
+// kill the goroutine running it (a worker, in BoundedScheduler's case,
+// which would otherwise permanently shrink the pool). Submit is a
+// general-purpose entry point, unlike Future.resolve (which always
+// recovers internally), so fn here can be anything a caller chose to
+// submit; both of the package's built-in Schedulers route through
+// runTask so they recover consistently.
+func runTask(fn func()) {
+	defer func() {
+		recover()
+	}()
+	fn()
+}
+
+// Submit queues fn for execution by one of the scheduler's workers. If
+// the queue is full, Submit's behavior depends on the scheduler's
+// QueuePolicy: it either blocks until a slot is free (QueueBlock) or
+// runs fn on the calling goroutine (QueueInline).
+func (s *BoundedScheduler) Submit(fn func()) {
+	if s.policy == QueueInline {
+		select {
+		case s.tasks <- fn:
+		default:
+			runTask(fn)
+		}
+		return
+	}
+	s.tasks <- fn
+}
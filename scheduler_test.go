@@ -0,0 +1,193 @@
+package async
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBoundedSchedulerLimitsConcurrency(t *testing.T) {
+	const workers = 2
+	const futures = 8
+
+	s := NewBoundedScheduler(workers)
+
+	var running atomic.Int32
+	var maxRunning atomic.Int32
+	release := make(chan struct{})
+
+	fs := make([]*Future[int], futures)
+	for i := range fs {
+		fs[i] = NewFuture(func() (int, error) {
+			n := running.Add(1)
+			for {
+				cur := maxRunning.Load()
+				if n <= cur || maxRunning.CompareAndSwap(cur, n) {
+					break
+				}
+			}
+			<-release
+			running.Add(-1)
+			return 0, nil
+		}).WithScheduler(s)
+		// Eager submits to the BoundedScheduler, which blocks once its
+		// workers and queue are all busy: submit concurrently so that
+		// submitting future N doesn't have to wait for an earlier one
+		// to be picked up by a worker.
+		go fs[i].Eager()
+	}
+
+	close(release)
+	for _, f := range fs {
+		<-f.Done()
+	}
+
+	if got := maxRunning.Load(); got > workers {
+		t.Fatalf("maxRunning: %d, want <= %d", got, workers)
+	}
+}
+
+func TestBoundedSchedulerQueueInline(t *testing.T) {
+	// A single worker and a queue of size 1: once f1 is being run by the
+	// worker and f2 occupies the only queue slot, the scheduler is
+	// saturated and a further submission must run inline.
+	s := NewBoundedSchedulerQueue(1, 1, QueueInline)
+
+	block := make(chan struct{})
+	started := make(chan struct{})
+	f1 := NewFuture(func() (int, error) {
+		close(started)
+		<-block
+		return 1, nil
+	}).WithScheduler(s)
+	f1.Eager()
+	<-started // the worker has dequeued f1, freeing the queue slot again
+
+	f2 := NewFuture(func() (int, error) {
+		return 2, nil
+	}).WithScheduler(s)
+	f2.Eager() // fills the now-free queue slot; the worker is still busy with f1
+
+	ran := make(chan struct{})
+	f3 := NewFuture(func() (int, error) {
+		close(ran)
+		return 3, nil
+	}).WithScheduler(s)
+
+	done := make(chan struct{})
+	go func() {
+		f3.Eager()
+		close(done)
+	}()
+
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("f3 was not run inline while the scheduler was saturated")
+	}
+	<-done
+
+	close(block)
+	f1.Result(context.Background())
+	f2.Result(context.Background())
+	f3.Result(context.Background())
+}
+
+func TestBoundedSchedulerWorkerSurvivesPanic(t *testing.T) {
+	s := NewBoundedScheduler(1)
+
+	s.Submit(func() { panic("boom") })
+
+	done := make(chan struct{})
+	s.Submit(func() { close(done) })
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("worker did not survive a panicking task")
+	}
+}
+
+func TestBoundedSchedulerInlineSurvivesPanic(t *testing.T) {
+	// Saturate the single worker and the single queue slot, then submit
+	// a panicking function that can only run inline: it must be
+	// recovered the same way a queued task would be.
+	s := NewBoundedSchedulerQueue(1, 1, QueueInline)
+
+	block := make(chan struct{})
+	started := make(chan struct{})
+	s.Submit(func() {
+		close(started)
+		<-block
+	})
+	<-started
+
+	s.Submit(func() {}) // fills the queue slot; the worker is still busy
+
+	done := make(chan struct{})
+	go func() {
+		s.Submit(func() { panic("boom") })
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("panic from an inline task escaped Submit")
+	}
+
+	close(block)
+}
+
+func TestGoroutineSchedulerRecoversPanic(t *testing.T) {
+	// goroutineScheduler must recover a panicking task just like
+	// BoundedScheduler does: Submit is a general-purpose entry point,
+	// and a user calling it directly (not just via Eager) shouldn't see
+	// different crash behavior depending on which built-in Scheduler
+	// they picked.
+	s := NewGoroutineScheduler()
+
+	s.Submit(func() { panic("boom") })
+
+	done := make(chan struct{})
+	s.Submit(func() { close(done) })
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("goroutineScheduler did not recover a panicking task")
+	}
+}
+
+func TestDefaultScheduler(t *testing.T) {
+	t.Cleanup(func() {
+		SetDefaultScheduler(NewGoroutineScheduler())
+	})
+
+	var used atomic.Bool
+	SetDefaultScheduler(schedulerFunc(func(fn func()) {
+		used.Store(true)
+		fn()
+	}))
+
+	f := NewFuture(func() (int, error) { return 42, nil })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	res, err := f.Result(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res != 42 {
+		t.Fatalf("res: %v", res)
+	}
+	if !used.Load() {
+		t.Fatal("expected the default scheduler to be used")
+	}
+}
+
+type schedulerFunc func(func())
+
+func (f schedulerFunc) Submit(fn func()) {
+	f(fn)
+}